@@ -0,0 +1,48 @@
+//go:build ent
+
+package api
+
+import (
+	"strings"
+	"time"
+)
+
+// License is the cluster's currently effective Enterprise license, as
+// returned by Operator.LicenseGet.
+type License struct {
+	LicenseID      string
+	CustomerID     string
+	IssueTime      time.Time
+	ExpirationTime time.Time
+	Features       []string
+	Trial          bool
+
+	// Source is either "file" (license_path/NOMAD_LICENSE) or "raft"
+	// (installed via LicensePut and replicated to the rest of the cluster).
+	Source string
+
+	// Warning is set when the license is in or past its grace period.
+	Warning string
+}
+
+// LicenseGet retrieves the cluster's currently effective license.
+func (op *Operator) LicenseGet(q *QueryOptions) (*License, *QueryMeta, error) {
+	var resp License
+	qm, err := op.c.query("/v1/operator/license", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// LicensePut installs a new license blob on the cluster and replicates it
+// to every server via Raft. blob is sent as the raw request body - not
+// JSON-encoded - since the HTTP handler on the other end reads it directly
+// and feeds it straight to ValidateLicense.
+func (op *Operator) LicensePut(blob string, q *WriteOptions) (*WriteMeta, error) {
+	wm, err := op.c.write("/v1/operator/license", strings.NewReader(blob), nil, q)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
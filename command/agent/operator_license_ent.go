@@ -0,0 +1,59 @@
+//go:build ent
+
+package agent
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// OperatorLicenseRequest handles the `/v1/operator/license` route, allowing
+// operators to fetch or install the cluster license without touching disk
+// on every server.
+func (s *HTTPServer) OperatorLicenseRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	switch req.Method {
+	case http.MethodGet:
+		return s.operatorLicenseGet(resp, req)
+	case http.MethodPut, http.MethodPost:
+		return s.operatorLicensePut(resp, req)
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) operatorLicenseGet(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	args := structs.GenericRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.LicenseGetResponse
+	if err := s.agent.RPC("License.Get", &args, &out); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &out.QueryMeta)
+	if out.Warning != "" {
+		resp.Header().Set("X-Nomad-License-Warning", out.Warning)
+	}
+	return out, nil
+}
+
+func (s *HTTPServer) operatorLicensePut(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	args := structs.LicenseUpsertRequest{License: string(body)}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.LicenseUpsertResponse
+	if err := s.agent.RPC("License.Put", &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+
+	return out, nil
+}
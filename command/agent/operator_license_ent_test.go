@@ -0,0 +1,30 @@
+//go:build ent
+
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHTTP_OperatorLicenseRequest_InvalidMethod covers the routing switch
+// in OperatorLicenseRequest: an unsupported verb must 405 before ever
+// touching s.agent, so this is safe to exercise against a bare HTTPServer.
+func TestHTTP_OperatorLicenseRequest_InvalidMethod(t *testing.T) {
+	ci.Parallel(t)
+
+	s := &HTTPServer{}
+	req := httptest.NewRequest(http.MethodDelete, "/v1/operator/license", nil)
+	resp := httptest.NewRecorder()
+
+	_, err := s.OperatorLicenseRequest(resp, req)
+	require.Error(t, err)
+
+	codedErr, ok := err.(HTTPCodedError)
+	require.True(t, ok, "expected a coded HTTP error, got %T", err)
+	require.Equal(t, 405, codedErr.Code())
+}
@@ -0,0 +1,73 @@
+//go:build ent
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad"
+)
+
+// OperatorDiagnoseCommand runs local preflight checks before a server is
+// started, starting with the Enterprise license. It never contacts a
+// running Nomad agent.
+type OperatorDiagnoseCommand struct {
+	Meta
+}
+
+func (c *OperatorDiagnoseCommand) Help() string {
+	helpText := `
+Usage: nomad operator diagnose [options]
+
+  Diagnose runs preflight checks against the server configuration on this
+  machine without starting a server, so a malformed license (or other
+  config problem) can be caught on a workstation or in CI instead of
+  failing "nomad agent" at startup.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsNoNamespace)
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorDiagnoseCommand) Synopsis() string {
+	return "Run preflight diagnostics against the local server configuration"
+}
+
+func (c *OperatorDiagnoseCommand) Name() string { return "operator diagnose" }
+
+func (c *OperatorDiagnoseCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	config, err := c.Meta.loadServerConfig(flags.Args())
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error loading configuration: %s", err))
+		return 1
+	}
+
+	report := (&nomad.LicenseWatcher{}).Diagnose(config.LicenseConfig)
+
+	if report.Error != nil {
+		c.Ui.Error(fmt.Sprintf("✗ license: %s", report.Error))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("✓ license: %s (source: %s, expires in %s)",
+		report.LicenseID, report.Source, report.TimeUntilExpiry.Truncate(time.Second)))
+	c.Ui.Output(fmt.Sprintf("  issue time:       %s", report.IssueTime))
+	c.Ui.Output(fmt.Sprintf("  expiration time:  %s", report.ExpirationTime))
+	c.Ui.Output(fmt.Sprintf("  features:         %s", strings.Join(report.Features, ", ")))
+
+	for _, w := range report.Warnings {
+		c.Ui.Warn(fmt.Sprintf("  warning: %s", w))
+	}
+
+	return 0
+}
@@ -0,0 +1,142 @@
+//go:build ent
+
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+type OperatorLicenseGetCommand struct {
+	Meta
+}
+
+func (c *OperatorLicenseGetCommand) Help() string {
+	helpText := `
+Usage: nomad license get [options]
+
+  Get retrieves the cluster's currently effective Enterprise license,
+  regardless of whether it was loaded from license_path/NOMAD_LICENSE on
+  this server or installed cluster-wide via "nomad license put".
+
+  If ACLs are enabled, this command requires a token with the
+  'operator:read' capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault)
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorLicenseGetCommand) Synopsis() string {
+	return "Display the cluster's current Enterprise license"
+}
+
+func (c *OperatorLicenseGetCommand) Name() string { return "license get" }
+
+func (c *OperatorLicenseGetCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	license, _, err := client.Operator().LicenseGet(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving license: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("License ID    = %s", license.LicenseID))
+	c.Ui.Output(fmt.Sprintf("Customer ID   = %s", license.CustomerID))
+	c.Ui.Output(fmt.Sprintf("Source        = %s", license.Source))
+	c.Ui.Output(fmt.Sprintf("Issue Time    = %s", license.IssueTime))
+	c.Ui.Output(fmt.Sprintf("Expiration    = %s", license.ExpirationTime))
+	c.Ui.Output(fmt.Sprintf("Trial         = %t", license.Trial))
+	c.Ui.Output(fmt.Sprintf("Features      = %s", strings.Join(license.Features, ", ")))
+
+	if license.Warning != "" {
+		c.Ui.Warn(fmt.Sprintf("\nWarning: %s", license.Warning))
+	}
+
+	return 0
+}
+
+type OperatorLicensePutCommand struct {
+	Meta
+}
+
+func (c *OperatorLicensePutCommand) Help() string {
+	helpText := `
+Usage: nomad license put [options] <path>
+
+  Put installs a new Enterprise license on the cluster. The license is
+  validated, installed on the server handling the request, and replicated
+  via Raft so every other server picks it up immediately. Use "-" to read
+  the license blob from stdin.
+
+  If ACLs are enabled, this command requires a token with the
+  'operator:write' capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault)
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorLicensePutCommand) Synopsis() string {
+	return "Install a new Enterprise license on the cluster"
+}
+
+func (c *OperatorLicensePutCommand) Name() string { return "license put" }
+
+func (c *OperatorLicensePutCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	var blob []byte
+	var err error
+	if args[0] == "-" {
+		blob, err = io.ReadAll(os.Stdin)
+	} else {
+		blob, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading license: %s", err))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	if _, err := client.Operator().LicensePut(strings.TrimSpace(string(blob)), nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error installing license: %s", err))
+		return 1
+	}
+
+	c.Ui.Output("License installed successfully")
+	return 0
+}
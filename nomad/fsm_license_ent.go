@@ -0,0 +1,45 @@
+//go:build ent
+
+package nomad
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// applyUpsertLicense is used to persist a cluster license received via Raft
+// and apply it to this server's own LicenseWatcher, so followers pick up a
+// license installed anywhere in the cluster without ever reading their own
+// license_path or NOMAD_LICENSE.
+func (n *nomadFSM) applyUpsertLicense(buf []byte, index uint64) interface{} {
+	var req structs.LicenseUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	applied, err := n.state.UpsertLicense(index, &state.StoredLicense{
+		Blob:      req.License,
+		IssueTime: req.IssueTime,
+	})
+	if err != nil {
+		n.logger.Error("UpsertLicense failed", "error", err)
+		return err
+	}
+	if !applied {
+		return structs.ErrLicenseNotNewer
+	}
+
+	if n.licenseWatcher == nil {
+		return nil
+	}
+
+	if err := n.licenseWatcher.ReloadFromRaft(req.License, time.Unix(req.IssueTime, 0)); err != nil {
+		n.logger.Error("failed to apply raft license to license watcher", "error", err)
+		return err
+	}
+
+	return nil
+}
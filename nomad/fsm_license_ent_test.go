@@ -0,0 +1,116 @@
+//go:build ent
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-licensing/v3"
+	"github.com/hashicorp/nomad/ci"
+	nomadLicense "github.com/hashicorp/nomad-licensing/license"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFSM_ApplyUpsertLicense_NoWatcher covers the follower-without-a-watcher
+// branch directly: applyUpsertLicense must still persist the license to the
+// state store even when licenseWatcher is nil, e.g. before a server's
+// LicenseWatcher has finished initializing.
+func TestFSM_ApplyUpsertLicense_NoWatcher(t *testing.T) {
+	ci.Parallel(t)
+
+	fsm := &nomadFSM{
+		state:  testStateStore(t),
+		logger: hclog.NewNullLogger(),
+	}
+
+	req := &structs.LicenseUpsertRequest{
+		License:   "test-blob",
+		IssueTime: 100,
+	}
+	buf, err := structs.Encode(structs.LicenseUpsertRequestType, req)
+	require.NoError(t, err)
+
+	resp := fsm.applyUpsertLicense(buf[1:], 10)
+	require.Nil(t, resp)
+
+	stored, err := fsm.state.License(nil)
+	require.NoError(t, err)
+	require.Equal(t, "test-blob", stored.Blob)
+	require.EqualValues(t, 100, stored.IssueTime)
+	require.EqualValues(t, 10, stored.CreateIndex)
+}
+
+// TestFSM_ApplyUpsertLicense_WatcherKeepsNewerLicense covers the round trip a
+// License.Put RPC drives end to end: the RPC raftApplies a
+// LicenseUpsertRequest, the FSM persists it to the state store, and then
+// calls ReloadFromRaft on the local LicenseWatcher. It seeds the watcher
+// with a license newer than the replicated one, so the exercised path is
+// ReloadFromRaft's not-newer guard - the one outcome that's safe to assert
+// without a real go-licensing Validator wired up to the watcher.
+func TestFSM_ApplyUpsertLicense_WatcherKeepsNewerLicense(t *testing.T) {
+	ci.Parallel(t)
+
+	lw := &LicenseWatcher{logger: hclog.NewNullLogger()}
+	lw.licenseInfo.Store(&ServerLicense{
+		license: &nomadLicense.License{License: &licensing.License{IssueTime: time.Unix(200, 0)}},
+		blob:    "existing-blob",
+		source:  licenseSourceFile,
+	})
+
+	fsm := &nomadFSM{
+		state:          testStateStore(t),
+		logger:         hclog.NewNullLogger(),
+		licenseWatcher: lw,
+	}
+
+	req := &structs.LicenseUpsertRequest{
+		License:   "test-blob",
+		IssueTime: 100,
+	}
+	buf, err := structs.Encode(structs.LicenseUpsertRequestType, req)
+	require.NoError(t, err)
+
+	resp := fsm.applyUpsertLicense(buf[1:], 10)
+	require.Nil(t, resp)
+
+	stored, err := fsm.state.License(nil)
+	require.NoError(t, err)
+	require.Equal(t, "test-blob", stored.Blob)
+
+	// The older replicated license must not have displaced the watcher's
+	// active license.
+	require.Equal(t, "existing-blob", lw.LicenseBlob())
+}
+
+// TestFSM_ApplyUpsertLicense_NotNewer covers the state-store no-op path:
+// applying a request whose IssueTime isn't strictly newer than what's
+// already stored must return structs.ErrLicenseNotNewer rather than nil, so
+// License.Put can tell a skipped upsert from a real one instead of both
+// reporting success.
+func TestFSM_ApplyUpsertLicense_NotNewer(t *testing.T) {
+	ci.Parallel(t)
+
+	fsm := &nomadFSM{
+		state:  testStateStore(t),
+		logger: hclog.NewNullLogger(),
+	}
+
+	first := &structs.LicenseUpsertRequest{License: "blob-v1", IssueTime: 100}
+	buf, err := structs.Encode(structs.LicenseUpsertRequestType, first)
+	require.NoError(t, err)
+	require.Nil(t, fsm.applyUpsertLicense(buf[1:], 10))
+
+	older := &structs.LicenseUpsertRequest{License: "blob-old", IssueTime: 50}
+	buf, err = structs.Encode(structs.LicenseUpsertRequestType, older)
+	require.NoError(t, err)
+
+	resp := fsm.applyUpsertLicense(buf[1:], 11)
+	require.Equal(t, structs.ErrLicenseNotNewer, resp)
+
+	stored, err := fsm.state.License(nil)
+	require.NoError(t, err)
+	require.Equal(t, "blob-v1", stored.Blob)
+}
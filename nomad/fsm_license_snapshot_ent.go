@@ -0,0 +1,44 @@
+//go:build ent
+
+package nomad
+
+import (
+	"github.com/hashicorp/go-msgpack/codec"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/raft"
+)
+
+// licenseSnapshotType is the SnapshotType byte used to persist the cluster
+// license table, numbered in the same enterprise-only range as
+// structs.LicenseUpsertRequestType so it can't collide with an OSS
+// SnapshotType added later.
+const licenseSnapshotType SnapshotType = 101
+
+// persistLicense writes the cluster's Raft-replicated license, if any, to
+// a snapshot. Without this the license table would be lost on snapshot
+// restore or log compaction, even though the Raft log itself replicated it
+// durably at apply time.
+func (s *nomadSnapshot) persistLicense(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	license, err := s.snap.License(nil)
+	if err != nil {
+		return err
+	}
+	if license == nil {
+		return nil
+	}
+
+	if _, err := sink.Write([]byte{byte(licenseSnapshotType)}); err != nil {
+		return err
+	}
+	return encoder.Encode(license)
+}
+
+// restoreLicense reads a license table entry persisted by persistLicense
+// back into the state store during snapshot restore.
+func restoreLicense(dec *codec.Decoder, restore *state.StateRestore) error {
+	license := new(state.StoredLicense)
+	if err := dec.Decode(license); err != nil {
+		return err
+	}
+	return restore.LicenseRestore(license)
+}
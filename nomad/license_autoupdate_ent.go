@@ -0,0 +1,146 @@
+//go:build ent
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+
+	nomadLicense "github.com/hashicorp/nomad-licensing/license"
+)
+
+// AutoUpdateConfig controls optional background license refresh from a
+// remote endpoint, configured via LicenseConfig.AutoUpdate. When Enabled,
+// LicenseWatcher periodically fetches URL and installs the result if it's
+// newer than the currently active license.
+type AutoUpdateConfig struct {
+	Enabled   bool
+	URL       string
+	AuthToken string
+	Interval  time.Duration
+	Jitter    time.Duration
+}
+
+// autoUpdate periodically fetches a renewed license blob from
+// cfg.AutoUpdate.URL and installs it if it's newer than the license
+// currently active, so an enterprise license can be rotated before
+// expiring without any operator action. A permanent license never expires
+// and is never refreshed.
+func (lw *LicenseWatcher) autoUpdate(ctx context.Context, cfg *AutoUpdateConfig) {
+	if lic := lw.License(); lic != nil && lic.LicenseID == permanentLicenseID {
+		lw.logger.Debug("license is permanent, skipping auto-update")
+		return
+	}
+
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+
+	var lastErrLog time.Time
+
+	for {
+		wait := interval
+		if cfg.Jitter > 0 {
+			wait += time.Duration(rand.Int63n(int64(cfg.Jitter)))
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if lic := lw.License(); lic != nil && lic.LicenseID == permanentLicenseID {
+			continue
+		}
+
+		if err := lw.refreshLicense(ctx, cfg); err != nil {
+			if time.Since(lastErrLog) > interval {
+				lw.logger.Error("failed to auto-update license", "error", err)
+				lastErrLog = time.Now()
+			}
+		}
+	}
+}
+
+// refreshLicense fetches a candidate license blob and installs it only if
+// it's newer (by IssueTime) than the currently active license. Combined
+// with Raft replication, installing it through raftInstall means the
+// refresh benefits every server in the cluster, not just whichever one
+// happened to fetch it.
+func (lw *LicenseWatcher) refreshLicense(ctx context.Context, cfg *AutoUpdateConfig) error {
+	blob, err := fetchLicense(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to fetch license: %w", err)
+	}
+
+	candidate, err := lw.ValidateLicense(blob)
+	if err != nil {
+		return fmt.Errorf("fetched license is invalid: %w", err)
+	}
+
+	return lw.installFetchedLicense(blob, candidate)
+}
+
+// installFetchedLicense decides what to do with a candidate license that
+// refreshLicense already fetched and validated: skip it if it isn't newer
+// than the active license, or replicate it via raft. Split out from
+// refreshLicense so the skip/error decisions are safe to unit test without
+// a real go-licensing Validator to produce a candidate license.
+func (lw *LicenseWatcher) installFetchedLicense(blob string, candidate *nomadLicense.License) error {
+	// Read licenseInfo directly rather than via License(), which panics when
+	// no license has been stored yet.
+	var cur *nomadLicense.License
+	if info, ok := lw.licenseInfo.Load().(*ServerLicense); ok {
+		cur = info.license
+	}
+	if cur != nil && !candidate.IssueTime.After(cur.IssueTime) {
+		lw.logger.Debug("fetched license is not newer than the active license, skipping")
+		return nil
+	}
+
+	if lw.raftInstall == nil {
+		return errors.New("auto-update cannot replicate license: no raft installer wired up")
+	}
+	if err := lw.raftInstall(blob); err != nil {
+		return fmt.Errorf("failed to replicate auto-updated license via raft: %w", err)
+	}
+
+	lw.logger.Info("replicated auto-updated license to the cluster", "issue_time", candidate.IssueTime)
+	return nil
+}
+
+func fetchLicense(ctx context.Context, cfg *AutoUpdateConfig) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.URL, nil)
+	if err != nil {
+		return "", err
+	}
+	if cfg.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+cfg.AuthToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d from %s", resp.StatusCode, cfg.URL)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
@@ -0,0 +1,139 @@
+//go:build ent
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-licensing/v3"
+	"github.com/hashicorp/nomad/ci"
+	nomadLicense "github.com/hashicorp/nomad-licensing/license"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicenseWatcher_autoUpdate_PermanentLicenseSkips(t *testing.T) {
+	ci.Parallel(t)
+
+	lw := &LicenseWatcher{logger: hclog.NewNullLogger()}
+	lw.licenseInfo.Store(&ServerLicense{
+		license: &nomadLicense.License{License: &licensing.License{LicenseID: permanentLicenseID}},
+		blob:    "permanent-blob",
+		source:  licenseSourceFile,
+	})
+
+	// A permanent license must make autoUpdate return immediately without
+	// ever touching cfg.URL - if it didn't, this would block waiting for the
+	// first timer tick, since a zero cfg.Interval falls back to 24 hours.
+	done := make(chan struct{})
+	go func() {
+		lw.autoUpdate(context.Background(), &AutoUpdateConfig{})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("autoUpdate did not return for a permanent license")
+	}
+}
+
+func TestLicenseWatcher_installFetchedLicense_NotNewerSkips(t *testing.T) {
+	ci.Parallel(t)
+
+	issueTime := time.Now()
+	lw := &LicenseWatcher{logger: hclog.NewNullLogger()}
+	lw.licenseInfo.Store(&ServerLicense{
+		license: &nomadLicense.License{License: &licensing.License{IssueTime: issueTime}},
+		blob:    "current-blob",
+		source:  licenseSourceFile,
+	})
+
+	candidate := &nomadLicense.License{License: &licensing.License{IssueTime: issueTime}}
+
+	// raftInstall is left nil: if the not-newer guard didn't short circuit
+	// before reaching it, this would return the missing-installer error
+	// instead of a clean skip.
+	require.NoError(t, lw.installFetchedLicense("candidate-blob", candidate))
+}
+
+func TestLicenseWatcher_installFetchedLicense_MissingRaftInstaller(t *testing.T) {
+	ci.Parallel(t)
+
+	lw := &LicenseWatcher{logger: hclog.NewNullLogger()}
+	candidate := &nomadLicense.License{License: &licensing.License{IssueTime: time.Now()}}
+
+	err := lw.installFetchedLicense("candidate-blob", candidate)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no raft installer wired up")
+}
+
+func TestLicenseWatcher_installFetchedLicense_InstallsNewer(t *testing.T) {
+	ci.Parallel(t)
+
+	issueTime := time.Now()
+	lw := &LicenseWatcher{logger: hclog.NewNullLogger()}
+	lw.licenseInfo.Store(&ServerLicense{
+		license: &nomadLicense.License{License: &licensing.License{IssueTime: issueTime.Add(-time.Hour)}},
+		blob:    "current-blob",
+		source:  licenseSourceFile,
+	})
+
+	var installed string
+	lw.SetRaftInstaller(func(blob string) error {
+		installed = blob
+		return nil
+	})
+
+	candidate := &nomadLicense.License{License: &licensing.License{IssueTime: issueTime}}
+	require.NoError(t, lw.installFetchedLicense("candidate-blob", candidate))
+	require.Equal(t, "candidate-blob", installed)
+}
+
+func TestLicenseWatcher_installFetchedLicense_RaftInstallError(t *testing.T) {
+	ci.Parallel(t)
+
+	lw := &LicenseWatcher{logger: hclog.NewNullLogger()}
+	lw.SetRaftInstaller(func(blob string) error {
+		return errors.New("raft apply failed")
+	})
+
+	candidate := &nomadLicense.License{License: &licensing.License{IssueTime: time.Now()}}
+	err := lw.installFetchedLicense("candidate-blob", candidate)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "raft apply failed")
+}
+
+func TestFetchLicense(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("sends bearer token and returns body", func(t *testing.T) {
+		var gotAuth string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotAuth = r.Header.Get("Authorization")
+			w.Write([]byte("license-blob"))
+		}))
+		defer srv.Close()
+
+		blob, err := fetchLicense(context.Background(), &AutoUpdateConfig{URL: srv.URL, AuthToken: "test-token"})
+		require.NoError(t, err)
+		require.Equal(t, "license-blob", blob)
+		require.Equal(t, "Bearer test-token", gotAuth)
+	})
+
+	t.Run("non-200 status is an error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		_, err := fetchLicense(context.Background(), &AutoUpdateConfig{URL: srv.URL})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unexpected status 500")
+	})
+}
@@ -0,0 +1,111 @@
+//go:build ent
+
+package nomad
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/hashicorp/go-licensing/v3"
+	nomadLicense "github.com/hashicorp/nomad-licensing/license"
+)
+
+// DiagnoseReport is the result of LicenseWatcher.Diagnose: a structured
+// summary of whether a license blob can be loaded and validated, without
+// ever starting a full server.
+type DiagnoseReport struct {
+	// Error is set, and every other field left zero, if the blob could not
+	// be read, parsed, or validated.
+	Error error
+
+	LicenseID       string
+	IssueTime       time.Time
+	ExpirationTime  time.Time
+	TerminationTime time.Time
+	TimeUntilExpiry time.Duration
+	Features        []string
+
+	// Source reports which of the three ways a license can be configured
+	// actually supplied the validated blob: the NOMAD_LICENSE environment
+	// variable, NOMAD_LICENSE_PATH, or the license_path field in the server
+	// config file. Raft isn't a possible value here since Diagnose never
+	// starts a server.
+	Source string
+
+	// Warnings are non-fatal issues go-licensing reported while validating
+	// the blob, e.g. use of a deprecated signing flag.
+	Warnings []string
+}
+
+// diagnoseSource reports which env var (if any) supplied the license blob
+// cfg.licenseString() resolved, falling back to the server config file's
+// license_path when neither is set.
+func diagnoseSource() string {
+	switch {
+	case os.Getenv("NOMAD_LICENSE") != "":
+		return "env (NOMAD_LICENSE)"
+	case os.Getenv("NOMAD_LICENSE_PATH") != "":
+		return "env (NOMAD_LICENSE_PATH)"
+	default:
+		return "path (license_path)"
+	}
+}
+
+// Diagnose exercises the same steps NewLicenseWatcher does to load and
+// validate a license - cfg.licenseString, cfg.validator, licensing.NewWatcher
+// and ValidateLicense - but never installs the result or starts a server.
+// It lets operators validate a license file on a workstation or in CI,
+// rather than discovering a malformed blob only when NewServer fails.
+func (lw *LicenseWatcher) Diagnose(cfg *LicenseConfig) *DiagnoseReport {
+	report := &DiagnoseReport{}
+
+	blob, err := cfg.licenseString()
+	if err != nil {
+		report.Error = err
+		return report
+	}
+	if blob == "" {
+		report.Error = errors.New("license is missing; configure \"license_path\" or the NOMAD_LICENSE/NOMAD_LICENSE_PATH environment variables")
+		return report
+	}
+
+	validator, err := cfg.validator()
+	if err != nil {
+		report.Error = err
+		return report
+	}
+
+	watcher, _, err := licensing.NewWatcher(&licensing.WatcherOptions{
+		InitLicense: blob,
+		Validator:   validator,
+	})
+	if err != nil {
+		report.Error = err
+		return report
+	}
+	defer watcher.Stop()
+
+	lic, err := watcher.ValidateLicense(blob)
+	if err != nil {
+		report.Error = err
+		return report
+	}
+
+	nLic, err := nomadLicense.NewLicense(lic)
+	if err != nil {
+		report.Error = err
+		return report
+	}
+
+	report.LicenseID = nLic.LicenseID
+	report.IssueTime = nLic.IssueTime
+	report.ExpirationTime = nLic.ExpirationTime
+	report.TerminationTime = nLic.TerminationTime
+	report.TimeUntilExpiry = time.Until(nLic.ExpirationTime)
+	report.Features = nLic.Features.StringList()
+	report.Source = diagnoseSource()
+	report.Warnings = lic.Warnings
+
+	return report
+}
@@ -0,0 +1,48 @@
+//go:build ent
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLicenseWatcher_Diagnose_MissingLicense covers the invalid-config path
+// that doesn't require a real go-licensing Validator: a config with no
+// license configured at all (license_path, NOMAD_LICENSE, and
+// NOMAD_LICENSE_PATH all unset) must fail before ever reaching
+// licensing.NewWatcher, with every other report field left zero.
+//
+// Diagnose against a valid config isn't covered here for the same reason
+// TestFSM_ApplyUpsertLicense_WatcherKeepsNewerLicense doesn't cover an
+// actual reload: exercising the
+// ValidateLicense success path needs a real signed license blob and
+// Validator, which this test suite has no fixture for.
+func TestLicenseWatcher_Diagnose_MissingLicense(t *testing.T) {
+	ci.Parallel(t)
+
+	lw := &LicenseWatcher{}
+	report := lw.Diagnose(&LicenseConfig{})
+
+	require.Error(t, report.Error)
+	require.Contains(t, report.Error.Error(), "license is missing")
+	require.Empty(t, report.LicenseID)
+	require.Empty(t, report.Features)
+	require.Empty(t, report.Source)
+}
+
+// TestDiagnoseSource is not run in parallel: t.Setenv panics in a test
+// that has called t.Parallel.
+func TestDiagnoseSource(t *testing.T) {
+	t.Setenv("NOMAD_LICENSE", "")
+	t.Setenv("NOMAD_LICENSE_PATH", "")
+	require.Equal(t, "path (license_path)", diagnoseSource())
+
+	t.Setenv("NOMAD_LICENSE_PATH", "/tmp/license")
+	require.Equal(t, "env (NOMAD_LICENSE_PATH)", diagnoseSource())
+
+	t.Setenv("NOMAD_LICENSE", "raw-blob")
+	require.Equal(t, "env (NOMAD_LICENSE)", diagnoseSource())
+}
@@ -0,0 +1,89 @@
+//go:build ent
+
+package nomad
+
+import (
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// License endpoint is used to install and inspect the cluster's Enterprise
+// license over RPC, backing the `operator license` HTTP routes and CLI.
+type License struct {
+	srv *Server
+}
+
+// Get returns the currently effective license, including which source
+// (file or raft) it was loaded from.
+func (l *License) Get(args *structs.GenericRequest, reply *structs.LicenseGetResponse) error {
+	if done, err := l.srv.forward("License.Get", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "license", "get"}, time.Now())
+
+	aclObj, err := l.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	lic := l.srv.licenseWatcher.License()
+	if lic == nil {
+		return structs.NewErrRPCCoded(404, "no license installed")
+	}
+
+	reply.LicenseID = lic.LicenseID
+	reply.CustomerID = lic.CustomerID
+	reply.IssueTime = lic.IssueTime
+	reply.ExpirationTime = lic.ExpirationTime
+	reply.Features = lic.Features.StringList()
+	reply.Trial = lic.TemporaryLicense
+	reply.Source = l.srv.licenseWatcher.Source()
+	reply.Warning = l.srv.licenseWatcher.Status().Warning
+
+	l.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
+// Put validates and installs a new license blob on this server, then
+// replicates it to the rest of the cluster via Raft so every server
+// applies it to its own LicenseWatcher.
+func (l *License) Put(args *structs.LicenseUpsertRequest, reply *structs.LicenseUpsertResponse) error {
+	if done, err := l.srv.forward("License.Put", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "license", "put"}, time.Now())
+
+	aclObj, err := l.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	lic, err := l.srv.licenseWatcher.ValidateLicense(args.License)
+	if err != nil {
+		return structs.NewErrRPCCoded(400, err.Error())
+	}
+	args.IssueTime = lic.IssueTime.Unix()
+
+	out, index, err := l.srv.raftApply(structs.LicenseUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+	if applyErr, ok := out.(error); ok && applyErr != nil {
+		if applyErr == structs.ErrLicenseNotNewer {
+			return structs.NewErrRPCCoded(409, applyErr.Error())
+		}
+		return applyErr
+	}
+
+	reply.Index = index
+	l.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
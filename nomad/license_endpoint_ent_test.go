@@ -0,0 +1,74 @@
+//go:build ent
+
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackcodec"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// noOperatorPolicy grants a token list-jobs in the default namespace and
+// nothing else, so it has no operator:read/operator:write capability -
+// ACLs deny any capability that isn't explicitly granted.
+const noOperatorPolicy = `
+namespace "default" {
+	capabilities = ["list-jobs"]
+}
+`
+
+// TestLicenseEndpoint_Get_ACLDenied covers the AllowOperatorRead branch:
+// a token without operator:read must be denied rather than seeing the
+// cluster's license.
+func TestLicenseEndpoint_Get_ACLDenied(t *testing.T) {
+	ci.Parallel(t)
+
+	s, _, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	denyToken := mock.CreatePolicyAndToken(t, s.State(), 1, "deny-operator", noOperatorPolicy)
+
+	get := &structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: denyToken.SecretID,
+		},
+	}
+	var resp structs.LicenseGetResponse
+	err := msgpackrpc.CallWithCodec(codec, "License.Get", get, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), structs.ErrPermissionDenied.Error())
+}
+
+// TestLicenseEndpoint_Put_ACLDenied covers the AllowOperatorWrite branch:
+// a token without operator:write must be denied before the submitted blob
+// is ever validated or raft-applied.
+func TestLicenseEndpoint_Put_ACLDenied(t *testing.T) {
+	ci.Parallel(t)
+
+	s, _, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	denyToken := mock.CreatePolicyAndToken(t, s.State(), 1, "deny-operator", noOperatorPolicy)
+
+	put := &structs.LicenseUpsertRequest{
+		License: "does-not-matter-acl-denied-first",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: denyToken.SecretID,
+		},
+	}
+	var resp structs.LicenseUpsertResponse
+	err := msgpackrpc.CallWithCodec(codec, "License.Put", put, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), structs.ErrPermissionDenied.Error())
+}
@@ -0,0 +1,67 @@
+//go:build ent
+
+package nomad
+
+import (
+	"fmt"
+	"time"
+
+	nomadLicense "github.com/hashicorp/nomad-licensing/license"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// reconcileLicenseFromRaft compares the Raft-replicated license against
+// whatever license the LicenseWatcher loaded from license_path/NOMAD_LICENSE
+// at startup, and prefers the Raft copy when it is newer. This is what
+// makes a license installed via the API durable across a restart or a
+// snapshot restore: without it, a server that recovers from a snapshot
+// (and so never replays the LicenseUpsertRequest log entry again) would
+// silently fall back to its stale file license.
+//
+// Callers should invoke this once, after both the FSM/state store and the
+// LicenseWatcher have finished initializing, but before the server starts
+// serving RPCs.
+func (s *Server) reconcileLicenseFromRaft() error {
+	stored, err := s.fsm.State().License(nil)
+	if err != nil {
+		return fmt.Errorf("failed to read raft-replicated license: %w", err)
+	}
+	if stored == nil {
+		return nil
+	}
+
+	issueTime := time.Unix(stored.IssueTime, 0)
+
+	if !raftLicenseIsNewer(s.licenseWatcher.License(), issueTime) {
+		s.logger.Debug("file license is newer than or equal to the raft-replicated license, keeping file license")
+		return nil
+	}
+
+	if err := s.licenseWatcher.ReloadFromRaft(stored.Blob, issueTime); err != nil {
+		return fmt.Errorf("failed to apply raft-replicated license at startup: %w", err)
+	}
+
+	s.logger.Info("startup license source resolved", "source", "raft", "issue_time", issueTime)
+	return nil
+}
+
+// raftLicenseIsNewer reports whether a raft-replicated license with the
+// given IssueTime should take precedence over cur, the license the
+// LicenseWatcher already loaded from license_path/NOMAD_LICENSE at
+// startup. Split out from reconcileLicenseFromRaft so the comparison is
+// safe to unit test without needing ReloadFromRaft's real go-licensing
+// Validator.
+func raftLicenseIsNewer(cur *nomadLicense.License, raftIssueTime time.Time) bool {
+	return cur == nil || raftIssueTime.After(cur.IssueTime)
+}
+
+// wireLicenseRaftInstaller gives the LicenseWatcher a way to replicate a
+// license (e.g. one it fetched via auto-update) to the rest of the cluster,
+// by routing it through the same RPC License.Put uses, which forwards to
+// the leader and raftApplies there. Called once during server setup,
+// alongside reconcileLicenseFromRaft.
+func (s *Server) wireLicenseRaftInstaller() {
+	s.licenseWatcher.SetRaftInstaller(func(blob string) error {
+		return s.RPC("License.Put", &structs.LicenseUpsertRequest{License: blob}, &structs.LicenseUpsertResponse{})
+	})
+}
@@ -0,0 +1,81 @@
+//go:build ent
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-licensing/v3"
+	"github.com/hashicorp/nomad/ci"
+	nomadLicense "github.com/hashicorp/nomad-licensing/license"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_reconcileLicenseFromRaft_NothingInRaft(t *testing.T) {
+	ci.Parallel(t)
+
+	lw := &LicenseWatcher{logger: hclog.NewNullLogger()}
+	lw.licenseInfo.Store(&ServerLicense{
+		license: &nomadLicense.License{License: &licensing.License{IssueTime: time.Unix(100, 0)}},
+		blob:    "file-blob",
+		source:  licenseSourceFile,
+	})
+
+	srv := &Server{
+		fsm:            &nomadFSM{state: testStateStore(t), logger: hclog.NewNullLogger()},
+		licenseWatcher: lw,
+		logger:         hclog.NewNullLogger(),
+	}
+
+	require.NoError(t, srv.reconcileLicenseFromRaft())
+	require.Equal(t, "file-blob", lw.LicenseBlob())
+}
+
+func TestServer_reconcileLicenseFromRaft_FileIsNewer(t *testing.T) {
+	ci.Parallel(t)
+
+	store := testStateStore(t)
+	applied, err := store.UpsertLicense(10, &state.StoredLicense{Blob: "raft-blob", IssueTime: 100})
+	require.NoError(t, err)
+	require.True(t, applied)
+
+	lw := &LicenseWatcher{logger: hclog.NewNullLogger()}
+	lw.licenseInfo.Store(&ServerLicense{
+		license: &nomadLicense.License{License: &licensing.License{IssueTime: time.Unix(200, 0)}},
+		blob:    "file-blob",
+		source:  licenseSourceFile,
+	})
+
+	srv := &Server{
+		fsm:            &nomadFSM{state: store, logger: hclog.NewNullLogger()},
+		licenseWatcher: lw,
+		logger:         hclog.NewNullLogger(),
+	}
+
+	require.NoError(t, srv.reconcileLicenseFromRaft())
+
+	// The file license is newer, so it must still be the active one - the
+	// older raft copy must not have displaced it.
+	require.Equal(t, "file-blob", lw.LicenseBlob())
+	require.Equal(t, string(licenseSourceFile), lw.Source())
+}
+
+// TestRaftLicenseIsNewer covers the "raft is newer" decision on its own:
+// actually exercising it through reconcileLicenseFromRaft would call
+// ReloadFromRaft -> setLicense with no real go-licensing Validator wired
+// up, which panics rather than erroring cleanly (see the same caveat on
+// TestLicenseWatcher_ReloadFromRaft_NotNewerIsNoop).
+func TestRaftLicenseIsNewer(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	cur := &nomadLicense.License{License: &licensing.License{IssueTime: now}}
+
+	require.True(t, raftLicenseIsNewer(nil, now))
+	require.True(t, raftLicenseIsNewer(cur, now.Add(time.Minute)))
+	require.False(t, raftLicenseIsNewer(cur, now))
+	require.False(t, raftLicenseIsNewer(cur, now.Add(-time.Minute)))
+}
@@ -17,17 +17,103 @@ import (
 	nomadLicense "github.com/hashicorp/nomad-licensing/license"
 )
 
+// licenseSource identifies where the watcher's currently active license blob
+// came from, so operators and support can tell why a given license is in
+// effect on a given server.
+type licenseSource string
+
+const (
+	licenseSourceFile licenseSource = "file"
+	licenseSourceRaft licenseSource = "raft"
+)
+
 const (
 	// permanentLicenseID is the license ID used for permanent (s3) enterprise builds
 	permanentLicenseID = "permanent"
 
 	licenseExpired = "license is no longer valid"
+
+	// defaultGracePeriod is used when LicenseConfig.GracePeriod is unset. It
+	// gives operators a week past expiration to install a new license before
+	// feature-gated RPCs start failing outright.
+	defaultGracePeriod = 7 * 24 * time.Hour
+
+	// degradedLogInterval bounds how often monitorWatcher logs about an
+	// in-grace or expired-past-grace license while the state is unchanged,
+	// so a long-expired license doesn't spam the logs.
+	degradedLogInterval = 5 * time.Minute
+)
+
+// watcherState is the LicenseWatcher's view of how healthy the current
+// license is, independent of which individual features it grants.
+type watcherState string
+
+const (
+	// stateValid means the license has not passed its ExpirationTime.
+	stateValid watcherState = "valid"
+
+	// stateInGrace means the license is past ExpirationTime but within
+	// GracePeriod; feature checks still succeed but carry a warning.
+	stateInGrace watcherState = "in_grace"
+
+	// stateExpiredPastGrace means the license is past ExpirationTime and
+	// GracePeriod; feature-gated RPCs start failing.
+	stateExpiredPastGrace watcherState = "expired_past_grace"
 )
 
+// LicenseStatus summarizes the watcher's current state for callers that
+// need to decide whether to surface a warning to operators, such as the
+// HTTP layer setting X-Nomad-License-Warning or the CLI printing a notice.
+type LicenseStatus struct {
+	State   watcherState
+	Warning string
+}
+
+// LicenseEventType describes why a LicenseEvent was published.
+type LicenseEventType string
+
+const (
+	// LicenseEventUpdated fires whenever a new license blob is installed,
+	// whether from a file reload, the API, or Raft replication.
+	LicenseEventUpdated LicenseEventType = "updated"
+
+	// LicenseEventWarning fires when go-licensing warns that the license is
+	// approaching expiration.
+	LicenseEventWarning LicenseEventType = "warning"
+
+	// LicenseEventError fires when go-licensing reports the license has
+	// expired or been terminated.
+	LicenseEventError LicenseEventType = "error"
+
+	// LicenseEventStateChanged fires when the watcher's grace-period state
+	// transitions, e.g. valid -> in_grace -> expired_past_grace.
+	LicenseEventStateChanged LicenseEventType = "state_changed"
+)
+
+// LicenseEvent is published to subscribers via LicenseWatcher.Subscribe so
+// in-process consumers (the scheduler, Sentinel, Consul/Vault integrations)
+// can react to a license change without polling FeatureCheck.
+//
+// This only fans out to in-process subscribers; it is not yet wired into
+// Nomad's HTTP event stream (the `event.stream` topics an operator can
+// subscribe to over the API), so there's currently no way to observe a
+// LicenseEvent from outside the process. That's left as follow-up work.
+type LicenseEvent struct {
+	Type    LicenseEventType
+	State   watcherState
+	License *nomadLicense.License
+	Warning string
+}
+
+// subscriberBufferSize bounds how many undelivered events a subscriber can
+// fall behind by before further events are dropped for it.
+const subscriberBufferSize = 4
+
 // ServerLicense contains an expanded license and its corresponding blob
 type ServerLicense struct {
 	license *nomadLicense.License
 	blob    string
+	source  licenseSource
 }
 
 type LicenseWatcher struct {
@@ -47,6 +133,41 @@ type LicenseWatcher struct {
 
 	// logTimes tracks the last time a log message was sent for a feature
 	logTimes map[nomadLicense.Features]time.Time
+
+	// gracePeriod is how long a license remains usable past its
+	// ExpirationTime before feature-gated RPCs start being rejected.
+	gracePeriod time.Duration
+
+	// stateMu guards state and lastStateLog, which together rate limit how
+	// often monitorWatcher logs about a degraded license: once per state
+	// transition, and at most once every degradedLogInterval afterwards.
+	stateMu      sync.Mutex
+	state        watcherState
+	lastStateLog time.Time
+
+	// subsMu guards subs, the set of in-process consumers registered via
+	// Subscribe.
+	subsMu sync.Mutex
+	subs   map[string]chan LicenseEvent
+
+	// cfg is retained so start can launch the optional auto-update
+	// goroutine without changing its call signature.
+	cfg *LicenseConfig
+
+	// raftInstall replicates a license blob to the whole cluster via the
+	// same path License.Put uses (RPC -> leader -> raftApply). It's nil
+	// until the server wires it up with SetRaftInstaller once Raft is
+	// available, which auto-update requires before it will install
+	// anything it fetches.
+	raftInstall func(blob string) error
+}
+
+// SetRaftInstaller wires up how the watcher replicates a license to the
+// rest of the cluster. Called once during server setup, after Raft is
+// available, so that features built on top of LicenseWatcher (currently
+// just auto-update) don't need their own reference to the server.
+func (lw *LicenseWatcher) SetRaftInstaller(fn func(blob string) error) {
+	lw.raftInstall = fn
 }
 
 func NewLicenseWatcher(logger hclog.Logger, cfg *LicenseConfig) (*LicenseWatcher, error) {
@@ -63,10 +184,19 @@ func NewLicenseWatcher(logger hclog.Logger, cfg *LicenseConfig) (*LicenseWatcher
 		return nil, errors.New("error unset BuildDate")
 	}
 
+	gracePeriod := cfg.GracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = defaultGracePeriod
+	}
+
 	lw := &LicenseWatcher{
 		fileLicense: blob,
 		logger:      logger.Named("licensing"),
 		logTimes:    make(map[nomadLicense.Features]time.Time),
+		gracePeriod: gracePeriod,
+		state:       stateValid,
+		subs:        make(map[string]chan LicenseEvent),
+		cfg:         cfg,
 	}
 
 	// Internally this calls licensing.ValidateLicense, so if the license is
@@ -117,7 +247,8 @@ func (lw *LicenseWatcher) Reload(cfg *LicenseConfig) error {
 	return lw.SetLicense(blob)
 }
 
-// License atomically returns the license watchers stored license
+// License atomically returns the license watchers stored license. Use
+// Source to determine whether it came from the file/env blob or from Raft.
 func (lw *LicenseWatcher) License() *nomadLicense.License {
 	return lw.licenseInfo.Load().(*ServerLicense).license
 }
@@ -153,8 +284,12 @@ func (lw *LicenseWatcher) Features() nomadLicense.Features {
 		return nomadLicense.FeatureNone
 	}
 
-	// check if our license is still valid
-	if _, err := lw.ValidateLicense(lw.FileLicense()); err != nil {
+	// Past the grace period a license grants no features at all, even
+	// though go-licensing would otherwise still consider it parseable.
+	// Within the grace period features are still granted, so FeatureCheck
+	// succeeds rather than hard failing. FeatureCheck itself carries no
+	// warning; the in-grace warning is only reported through Status.
+	if lw.currentState(lic, time.Now()) == stateExpiredPastGrace {
 		return nomadLicense.FeatureNone
 	}
 
@@ -185,8 +320,139 @@ func (lw *LicenseWatcher) FeatureCheck(feature nomadLicense.Features, emitLog bo
 	return err
 }
 
+// Status reports the watcher's current grace-period state and, while
+// degraded, a warning message that RPC handlers can propagate to clients
+// (e.g. as an X-Nomad-License-Warning header) or the CLI can print as a
+// notice. Unlike the logger.Warn monitorWatcher emits, this warning is
+// always populated while degraded: it's read on every License.Get/CLI
+// invocation, so operators polling during the grace period must see it
+// every time, not just once per log interval. Status is a pure read: it
+// has no side effects and does not itself rate limit or publish events.
+func (lw *LicenseWatcher) Status() LicenseStatus {
+	lic := lw.License()
+	if lic == nil {
+		return LicenseStatus{State: stateExpiredPastGrace}
+	}
+
+	now := time.Now()
+	state := lw.currentState(lic, now)
+
+	status := LicenseStatus{State: state}
+	if state != stateValid {
+		status.Warning = degradedWarning(state, lic, lw.gracePeriod, now)
+	}
+
+	return status
+}
+
+// currentState derives the watcher's grace-period state from lic's
+// ExpirationTime without any side effects, so it's safe to call from
+// Features() on every feature check. A license HashiCorp has explicitly
+// terminated goes straight to stateExpiredPastGrace with no grace period,
+// regardless of how far away ExpirationTime still is.
+func (lw *LicenseWatcher) currentState(lic *nomadLicense.License, now time.Time) watcherState {
+	switch {
+	case !lic.TerminationTime.IsZero() && now.After(lic.TerminationTime):
+		return stateExpiredPastGrace
+	case now.After(lic.ExpirationTime.Add(lw.gracePeriod)):
+		return stateExpiredPastGrace
+	case now.After(lic.ExpirationTime):
+		return stateInGrace
+	default:
+		return stateValid
+	}
+}
+
+// degradedWarning renders the operator-facing message for a degraded
+// state. It's shared by Status, which returns it unconditionally, and
+// monitorWatcher, which logs it only when checkStateTransition says to.
+func degradedWarning(state watcherState, lic *nomadLicense.License, gracePeriod time.Duration, now time.Time) string {
+	switch state {
+	case stateInGrace:
+		return fmt.Sprintf("license expired %s ago and is in its grace period; install a new license within %s to avoid losing access to licensed features",
+			now.Sub(lic.ExpirationTime).Truncate(time.Second), gracePeriod)
+	case stateExpiredPastGrace:
+		return "license's grace period has ended; licensed features are disabled until a new license is installed"
+	default:
+		return ""
+	}
+}
+
+// checkStateTransition is called periodically by monitorWatcher - never
+// from the Status() read path - to publish a state_changed event on every
+// transition and to log the degraded warning at most once per transition
+// plus once every degradedLogInterval while it persists.
+func (lw *LicenseWatcher) checkStateTransition() {
+	lic := lw.License()
+	if lic == nil {
+		return
+	}
+
+	now := time.Now()
+	state := lw.currentState(lic, now)
+
+	transitioned, shouldLog := lw.noteState(state, now)
+	if transitioned {
+		lw.publish(LicenseEvent{Type: LicenseEventStateChanged, State: state, License: lic})
+	}
+
+	if state == stateValid || !shouldLog {
+		return
+	}
+
+	lw.logger.Warn(degradedWarning(state, lic, lw.gracePeriod, now), "state", state)
+}
+
+// noteState records the watcher's latest computed state and reports whether
+// it just transitioned, plus whether a degraded-state log is due: once per
+// transition, and afterwards at most once per degradedLogInterval.
+func (lw *LicenseWatcher) noteState(state watcherState, now time.Time) (transitioned, shouldLog bool) {
+	lw.stateMu.Lock()
+	defer lw.stateMu.Unlock()
+
+	transitioned = state != lw.state
+	due := !lw.lastStateLog.IsZero() && now.Sub(lw.lastStateLog) > degradedLogInterval
+	shouldLog = transitioned || due
+
+	lw.state = state
+	if shouldLog {
+		lw.lastStateLog = now
+	}
+	return transitioned, shouldLog
+}
+
 // SetLicense sets the server's license
 func (lw *LicenseWatcher) SetLicense(blob string) error {
+	return lw.setLicense(blob, licenseSourceFile)
+}
+
+// ReloadFromRaft installs a license blob that was replicated through Raft,
+// either by the FSM applying a new LicenseUpsertRequest or by the server
+// comparing the Raft-stored license against its file license at startup. It
+// is a no-op if the Raft copy is not newer than whatever license is
+// currently active, so an older replayed or out-of-order apply can never
+// regress a server's license.
+func (lw *LicenseWatcher) ReloadFromRaft(blob string, issueTime time.Time) error {
+	if cur := lw.License(); cur != nil && !issueTime.After(cur.IssueTime) {
+		return nil
+	}
+
+	if err := lw.setLicense(blob, licenseSourceRaft); err != nil {
+		return err
+	}
+
+	lw.logger.Info("installed license replicated via raft", "issue_time", issueTime, "source", licenseSourceRaft)
+	return nil
+}
+
+// Source reports whether the currently active license came from the
+// server's license_path/NOMAD_LICENSE ("file") or was replicated via Raft
+// ("raft"), so operators can tell why a given license is in effect.
+func (lw *LicenseWatcher) Source() string {
+	return string(lw.licenseInfo.Load().(*ServerLicense).source)
+}
+
+func (lw *LicenseWatcher) setLicense(blob string, source licenseSource) error {
 	blob = strings.TrimRight(blob, "\r\n")
 
 	_, err := lw.watcher.ValidateLicense(blob)
@@ -213,11 +479,57 @@ func (lw *LicenseWatcher) SetLicense(blob string) error {
 	lw.licenseInfo.Store(&ServerLicense{
 		license: license,
 		blob:    blob,
+		source:  source,
 	})
 
+	lw.publish(LicenseEvent{Type: LicenseEventUpdated, License: license})
+
 	return nil
 }
 
+// Subscribe registers a new consumer of license change events, identified
+// by name for logging purposes (duplicate names are fine; each call gets
+// its own channel). The returned func unsubscribes and must be called to
+// release the channel once the consumer is done.
+//
+// Events are delivered on a best-effort basis: a consumer that doesn't keep
+// up has events dropped for it rather than blocking the watcher or other
+// subscribers.
+func (lw *LicenseWatcher) Subscribe(name string) (<-chan LicenseEvent, func()) {
+	lw.subsMu.Lock()
+	defer lw.subsMu.Unlock()
+
+	ch := make(chan LicenseEvent, subscriberBufferSize)
+	key := fmt.Sprintf("%s-%p", name, ch)
+	lw.subs[key] = ch
+
+	unsubscribe := func() {
+		lw.subsMu.Lock()
+		defer lw.subsMu.Unlock()
+		if _, ok := lw.subs[key]; ok {
+			delete(lw.subs, key)
+			close(ch)
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+// publish fans event out to every subscriber, dropping it for any
+// subscriber whose buffer is full rather than blocking.
+func (lw *LicenseWatcher) publish(event LicenseEvent) {
+	lw.subsMu.Lock()
+	defer lw.subsMu.Unlock()
+
+	for name, ch := range lw.subs {
+		select {
+		case ch <- event:
+		default:
+			lw.logger.Warn("license event dropped for slow subscriber", "subscriber", name, "event", event.Type)
+		}
+	}
+}
+
 func (lw *LicenseWatcher) hasFeature(feature nomadLicense.Features) bool {
 	return lw.Features().HasFeature(feature)
 }
@@ -226,6 +538,10 @@ func (lw *LicenseWatcher) hasFeature(feature nomadLicense.Features) bool {
 // for ensuring it is shut down properly
 func (lw *LicenseWatcher) start(ctx context.Context) {
 	go lw.monitorWatcher(ctx)
+
+	if lw.cfg.AutoUpdate != nil && lw.cfg.AutoUpdate.Enabled {
+		go lw.autoUpdate(ctx, lw.cfg.AutoUpdate)
+	}
 }
 
 // monitorWatcher monitors the LicenseWatchers go-licensing watcher channels
@@ -261,12 +577,32 @@ func (lw *LicenseWatcher) monitorWatcher(ctx context.Context) {
 		// restarted.
 		case err := <-lw.watcher.ErrorCh():
 			lw.logger.Error("license expired, please update license", "error", err)
+			lw.publish(LicenseEvent{Type: LicenseEventError, Warning: err.Error()})
 
 		case warnLicense := <-lw.watcher.WarningCh():
 			lw.logger.Warn("license expiring", "time_left", time.Until(warnLicense.ExpirationTime).Truncate(time.Second))
+			lw.publish(LicenseEvent{Type: LicenseEventWarning})
 
 		case <-metricsTicker.C:
 			metrics.SetGauge([]string{"license", "expiration_time_epoch"}, float32(lw.License().ExpirationTime.Unix()))
+
+			lw.checkStateTransition()
+			metrics.SetGauge([]string{"license", "status"}, float32(licenseStatusGaugeValue(lw.Status().State)))
 		}
 	}
+}
+
+// licenseStatusGaugeValue maps a watcherState to the numeric value emitted
+// on the nomad.license.status gauge: 0 is healthy, higher is worse.
+func licenseStatusGaugeValue(state watcherState) int {
+	switch state {
+	case stateValid:
+		return 0
+	case stateInGrace:
+		return 1
+	case stateExpiredPastGrace:
+		return 2
+	default:
+		return -1
+	}
 }
\ No newline at end of file
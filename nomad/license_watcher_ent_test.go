@@ -0,0 +1,170 @@
+//go:build ent
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-licensing/v3"
+	"github.com/hashicorp/nomad/ci"
+	nomadLicense "github.com/hashicorp/nomad-licensing/license"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicenseWatcher_currentState(t *testing.T) {
+	ci.Parallel(t)
+
+	lw := &LicenseWatcher{gracePeriod: time.Hour}
+	now := time.Now()
+	lic := &nomadLicense.License{License: &licensing.License{}}
+
+	lic.ExpirationTime = now.Add(time.Minute)
+	require.Equal(t, stateValid, lw.currentState(lic, now))
+
+	lic.ExpirationTime = now.Add(-time.Minute)
+	require.Equal(t, stateInGrace, lw.currentState(lic, now))
+
+	lic.ExpirationTime = now.Add(-2 * time.Hour)
+	require.Equal(t, stateExpiredPastGrace, lw.currentState(lic, now))
+
+	// A terminated license is expired-past-grace immediately, even with an
+	// ExpirationTime far in the future.
+	lic.ExpirationTime = now.Add(24 * time.Hour)
+	lic.TerminationTime = now.Add(-time.Minute)
+	require.Equal(t, stateExpiredPastGrace, lw.currentState(lic, now))
+}
+
+func TestLicenseWatcher_noteState(t *testing.T) {
+	ci.Parallel(t)
+
+	lw := &LicenseWatcher{state: stateValid}
+	now := time.Now()
+
+	// Same state, no transition: not yet due either.
+	transitioned, shouldLog := lw.noteState(stateValid, now)
+	require.False(t, transitioned)
+	require.False(t, shouldLog)
+
+	// A transition always logs, regardless of timing.
+	transitioned, shouldLog = lw.noteState(stateInGrace, now)
+	require.True(t, transitioned)
+	require.True(t, shouldLog)
+
+	// Same state again immediately after: no transition, and not yet due.
+	transitioned, shouldLog = lw.noteState(stateInGrace, now.Add(time.Second))
+	require.False(t, transitioned)
+	require.False(t, shouldLog)
+
+	// Same state, but past the rate-limit interval: due again even without
+	// a transition.
+	transitioned, shouldLog = lw.noteState(stateInGrace, now.Add(degradedLogInterval+time.Second))
+	require.False(t, transitioned)
+	require.True(t, shouldLog)
+}
+
+func TestLicenseWatcher_Status_WarningAlwaysPopulatedWhileDegraded(t *testing.T) {
+	ci.Parallel(t)
+
+	lw := &LicenseWatcher{gracePeriod: time.Hour, state: stateValid}
+	lic := &nomadLicense.License{License: &licensing.License{
+		ExpirationTime: time.Now().Add(-time.Minute),
+	}}
+	lw.licenseInfo.Store(&ServerLicense{license: lic, source: licenseSourceFile})
+
+	// Status must report the warning on every call while degraded, not
+	// just the first: it backs API/CLI reads, which are not rate limited
+	// the way monitorWatcher's logging is.
+	for i := 0; i < 3; i++ {
+		status := lw.Status()
+		require.Equal(t, stateInGrace, status.State)
+		require.NotEmpty(t, status.Warning)
+	}
+}
+
+func TestLicenseWatcher_ReloadFromRaft_NotNewerIsNoop(t *testing.T) {
+	ci.Parallel(t)
+
+	issueTime := time.Now().Add(-time.Hour)
+	lic := &nomadLicense.License{License: &licensing.License{IssueTime: issueTime}}
+
+	lw := &LicenseWatcher{logger: hclog.NewNullLogger()}
+	lw.licenseInfo.Store(&ServerLicense{license: lic, blob: "current-blob", source: licenseSourceFile})
+
+	// An equal-or-older IssueTime must no-op without touching lw.watcher,
+	// which is nil here - if the guard didn't short circuit, this would
+	// panic instead of returning cleanly.
+	require.NoError(t, lw.ReloadFromRaft("older-blob", issueTime))
+	require.Equal(t, "current-blob", lw.LicenseBlob())
+	require.Equal(t, string(licenseSourceFile), lw.Source())
+}
+
+func TestLicenseWatcher_Subscribe_DeliversToAllSubscribers(t *testing.T) {
+	ci.Parallel(t)
+
+	lw := &LicenseWatcher{logger: hclog.NewNullLogger(), subs: make(map[string]chan LicenseEvent)}
+
+	ch1, unsub1 := lw.Subscribe("one")
+	defer unsub1()
+	ch2, unsub2 := lw.Subscribe("two")
+	defer unsub2()
+
+	lw.publish(LicenseEvent{Type: LicenseEventUpdated})
+
+	select {
+	case ev := <-ch1:
+		require.Equal(t, LicenseEventUpdated, ev.Type)
+	default:
+		t.Fatal("subscriber one did not receive the event")
+	}
+	select {
+	case ev := <-ch2:
+		require.Equal(t, LicenseEventUpdated, ev.Type)
+	default:
+		t.Fatal("subscriber two did not receive the event")
+	}
+}
+
+func TestLicenseWatcher_publish_DropsForSlowSubscriber(t *testing.T) {
+	ci.Parallel(t)
+
+	lw := &LicenseWatcher{logger: hclog.NewNullLogger(), subs: make(map[string]chan LicenseEvent)}
+
+	ch, unsub := lw.Subscribe("slow")
+	defer unsub()
+
+	// Fill the subscriber's buffer, then publish one more than it can
+	// hold: the extra event must be dropped rather than publish blocking
+	// on the full channel.
+	for i := 0; i < subscriberBufferSize; i++ {
+		lw.publish(LicenseEvent{Type: LicenseEventUpdated})
+	}
+	lw.publish(LicenseEvent{Type: LicenseEventStateChanged})
+
+	for i := 0; i < subscriberBufferSize; i++ {
+		ev := <-ch
+		require.Equal(t, LicenseEventUpdated, ev.Type)
+	}
+
+	select {
+	case ev := <-ch:
+		t.Fatalf("expected the dropped event to never be delivered, got %v", ev.Type)
+	default:
+	}
+}
+
+func TestLicenseWatcher_Subscribe_UnsubscribeClosesChannel(t *testing.T) {
+	ci.Parallel(t)
+
+	lw := &LicenseWatcher{logger: hclog.NewNullLogger(), subs: make(map[string]chan LicenseEvent)}
+
+	ch, unsub := lw.Subscribe("consumer")
+	unsub()
+
+	// publish must not panic or block after the subscriber has gone away.
+	lw.publish(LicenseEvent{Type: LicenseEventUpdated})
+
+	_, ok := <-ch
+	require.False(t, ok)
+}
@@ -0,0 +1,116 @@
+//go:build ent
+
+package state
+
+import (
+	"fmt"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const TableLicense = "license"
+
+func init() {
+	RegisterSchemaFactory(licenseTableSchema)
+}
+
+// licenseTableSchema keeps the single most recently installed cluster
+// license, so that every server can recover the Raft-replicated license on
+// restart without waiting to hear from the leader again.
+func licenseTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableLicense,
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "ID",
+				},
+			},
+		},
+	}
+}
+
+// StoredLicense is the Raft-replicated record of the cluster's current
+// license. ID is always singletonLicenseID so that UpsertLicense always
+// overwrites the prior entry rather than
+// accumulating history.
+type StoredLicense struct {
+	ID        string
+	Blob      string
+	IssueTime int64
+
+	structs.RaftIndex
+}
+
+// UpsertLicense installs a Raft-replicated license blob, but only if it is
+// newer (by IssueTime) than whatever is currently stored. Older blobs are
+// dropped so that a replay or a late-arriving follower update can never roll
+// a license backwards; the returned bool reports whether the blob was
+// actually applied, so callers can tell a drop from a real upsert instead of
+// both looking like a nil error.
+func (s *StateStore) UpsertLicense(index uint64, license *StoredLicense) (bool, error) {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	existing, err := txn.First(TableLicense, "id", singletonLicenseID)
+	if err != nil {
+		return false, fmt.Errorf("license lookup failed: %v", err)
+	}
+	if existing != nil && existing.(*StoredLicense).IssueTime >= license.IssueTime {
+		return false, nil
+	}
+
+	license.ID = singletonLicenseID
+	license.ModifyIndex = index
+	if existing == nil {
+		license.CreateIndex = index
+	} else {
+		license.CreateIndex = existing.(*StoredLicense).CreateIndex
+	}
+
+	if err := txn.Insert(TableLicense, license); err != nil {
+		return false, fmt.Errorf("license insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{TableLicense, index}); err != nil {
+		return false, fmt.Errorf("index update failed: %v", err)
+	}
+
+	if err := txn.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// License returns the Raft-replicated cluster license, or nil if none has
+// ever been installed through Raft.
+func (s *StateStore) License(ws memdb.WatchSet) (*StoredLicense, error) {
+	txn := s.db.ReadTxn()
+	defer txn.Abort()
+
+	watchCh, existing, err := txn.FirstWatch(TableLicense, "id", singletonLicenseID)
+	if err != nil {
+		return nil, fmt.Errorf("license lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing == nil {
+		return nil, nil
+	}
+	return existing.(*StoredLicense), nil
+}
+
+// singletonLicenseID is the fixed key under which the cluster's single
+// current license is stored; Nomad only ever tracks one active license per
+// cluster at a time.
+const singletonLicenseID = "cluster-license"
+
+// LicenseRestore is used to restore a license table entry from a snapshot,
+// bypassing the IssueTime comparison UpsertLicense does since a snapshot is
+// always authoritative for the index it was taken at.
+func (r *StateRestore) LicenseRestore(license *StoredLicense) error {
+	return r.txn.Insert(TableLicense, license)
+}
@@ -0,0 +1,61 @@
+//go:build ent
+
+package state
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateStore_UpsertLicense(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	first := &StoredLicense{Blob: "blob-v1", IssueTime: 100}
+	applied, err := store.UpsertLicense(10, first)
+	require.NoError(t, err)
+	require.True(t, applied)
+
+	got, err := store.License(nil)
+	require.NoError(t, err)
+	require.Equal(t, "blob-v1", got.Blob)
+	require.EqualValues(t, 10, got.CreateIndex)
+	require.EqualValues(t, 10, got.ModifyIndex)
+
+	// An older IssueTime is dropped: neither the blob nor the index should
+	// change, and applied reports false so the caller can tell it was a
+	// no-op.
+	older := &StoredLicense{Blob: "blob-old", IssueTime: 50}
+	applied, err = store.UpsertLicense(11, older)
+	require.NoError(t, err)
+	require.False(t, applied)
+
+	got, err = store.License(nil)
+	require.NoError(t, err)
+	require.Equal(t, "blob-v1", got.Blob)
+	require.EqualValues(t, 10, got.ModifyIndex)
+
+	// A strictly newer IssueTime replaces the blob and bumps ModifyIndex,
+	// but CreateIndex is preserved from the original insert.
+	newer := &StoredLicense{Blob: "blob-v2", IssueTime: 200}
+	applied, err = store.UpsertLicense(12, newer)
+	require.NoError(t, err)
+	require.True(t, applied)
+
+	got, err = store.License(nil)
+	require.NoError(t, err)
+	require.Equal(t, "blob-v2", got.Blob)
+	require.EqualValues(t, 10, got.CreateIndex)
+	require.EqualValues(t, 12, got.ModifyIndex)
+}
+
+func TestStateStore_License_NoneInstalled(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	got, err := store.License(nil)
+	require.NoError(t, err)
+	require.Nil(t, got)
+}
@@ -0,0 +1,63 @@
+//go:build ent
+
+package structs
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrLicenseNotNewer is returned by the FSM's license upsert when the
+// submitted license's IssueTime is not strictly newer than the cluster's
+// currently installed license, so the upsert was skipped rather than
+// applied. License.Put reports it to the caller as a 409 coded error.
+var ErrLicenseNotNewer = errors.New("license is not newer than the currently installed license")
+
+// LicenseUpsertRequestType is the Raft message type used to replicate a
+// cluster license to every server. Enterprise message types are numbered
+// starting well above the OSS range so that new OSS message types can be
+// added without colliding with them.
+const LicenseUpsertRequestType MessageType = 101
+
+// LicenseUpsertRequest is used by the leader to persist a newly installed
+// license through Raft so that every server in the cluster applies it to its
+// local LicenseWatcher.
+type LicenseUpsertRequest struct {
+	// License is the signed license blob as given to LicenseWatcher.SetLicense.
+	License string
+
+	// IssueTime is carried alongside the blob so the FSM and followers can
+	// cheaply compare it against their currently installed license without
+	// first validating the blob.
+	IssueTime int64
+
+	WriteRequest
+}
+
+// LicenseUpsertResponse is returned once a LicenseUpsertRequest has been
+// committed to Raft and applied to the leader's own LicenseWatcher.
+type LicenseUpsertResponse struct {
+	QueryMeta
+}
+
+// LicenseGetResponse describes the cluster's currently effective license,
+// returned by the `operator license` HTTP route and CLI.
+type LicenseGetResponse struct {
+	LicenseID      string
+	CustomerID     string
+	IssueTime      time.Time
+	ExpirationTime time.Time
+	Features       []string
+	Trial          bool
+
+	// Source is either "file" (license_path/NOMAD_LICENSE) or "raft"
+	// (installed on some server via the API and replicated).
+	Source string
+
+	// Warning is set when the license is in or past its grace period. HTTP
+	// handlers propagate it as an X-Nomad-License-Warning header and the
+	// CLI prints it as a notice.
+	Warning string
+
+	QueryMeta
+}